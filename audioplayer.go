@@ -6,8 +6,8 @@ import (
 	"io"
 	"log"
 	"os/exec"
-	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hajimehoshi/oto"
 )
@@ -28,15 +28,30 @@ type AudioPlayer struct {
 	ffmpegCmd      *exec.Cmd
 	player         *oto.Player
 	context        *oto.Context
-	audioFile      string
+	reader         *readerCtx
+	recorder       *recorder
+	source         Source
+	resolvedInput  string // the value ap.source.Input last resolved to, cached for Duration
+	bytesWritten   int64  // atomic; bytes of decoded PCM written to the Oto player
+
+	eventsMu sync.Mutex // guards events separately so it can be read while ap.mu is held
+	events   chan Event
 
 	speed     float64 // must be between 0.5 and 2.0
 	startTime int     // in seconds
+	paused    bool
 }
 
+// NewAudioPlayer creates an AudioPlayer that plays a local audio file.
 func NewAudioPlayer(audioFile string, speed float64, startTime int) *AudioPlayer {
+	return NewAudioPlayerWithSource(FileSource(audioFile), speed, startTime)
+}
+
+// NewAudioPlayerWithSource creates an AudioPlayer that plays the given Source, e.g. an
+// io.Reader, an HTTP(S) URL, or a youtube.Source.
+func NewAudioPlayerWithSource(source Source, speed float64, startTime int) *AudioPlayer {
 	return &AudioPlayer{
-		audioFile: audioFile,
+		source:    source,
 		speed:     speed,
 		startTime: startTime,
 	}
@@ -54,30 +69,16 @@ func (ap *AudioPlayer) Start() error {
 		ap.mu.Lock()
 	}
 
-	// FFmpeg command to decode audio into raw PCM
-	ffmpegCmd := exec.Command(
-		ffmpegPath,
-		"-ss", strconv.Itoa(ap.startTime),
-		"-i", ap.audioFile,
-		"-filter:a", "atempo="+strconv.FormatFloat(ap.speed, 'f', -1, 64),
-		"-f", "s16le",
-		"-ar", "44100",
-		"-ac", "2",
-		"pipe:1",
-	)
-
-	ffmpegOut, err := ffmpegCmd.StdoutPipe()
+	pipeline, err := startFFmpegPipeline(ap.source, ap.speed, ap.startTime, &ap.wg)
 	if err != nil {
-		return fmt.Errorf("create FFmpeg stdout pipe: %w", err)
+		return err
 	}
+	ap.cancelPlayback = pipeline.cancel
+	ap.resolvedInput = pipeline.resolvedInput
 
-	ctx, cancel := context.WithCancel(context.Background())
-	ap.cancelPlayback = cancel
-	reader := NewReader(ctx, ffmpegOut)
-
-	if err := ffmpegCmd.Start(); err != nil {
-		return fmt.Errorf("start FFmpeg cmd: %w", err)
-	}
+	reader := pipeline.reader
+	ap.reader = reader
+	ap.paused = false
 
 	// Create Oto context and player if not already initialized
 	if ap.context == nil {
@@ -90,17 +91,25 @@ func (ap *AudioPlayer) Start() error {
 		ap.context = context
 	}
 
+	atomic.StoreInt64(&ap.bytesWritten, 0)
+
 	ap.wg.Add(1)
 	ap.player = ap.context.NewPlayer()
+	counted := &countingWriter{w: ap.player, n: &ap.bytesWritten}
 	go func() {
-		if _, err := io.Copy(ap.player, reader); err != nil {
-			log.Printf("Error copying audio data: %v", err)
+		_, copyErr := io.Copy(counted, reader)
+		switch {
+		case copyErr != nil && reader.ctx.Err() == nil:
+			ap.emitEvent(Event{Type: EventError, Err: copyErr})
+		case copyErr == nil:
+			ap.emitEvent(Event{Type: EventEOF})
 		}
 		ap.wg.Done()
 		ap.Stop()
 	}()
 
-	ap.ffmpegCmd = ffmpegCmd
+	ap.ffmpegCmd = pipeline.cmd
+	ap.emitEvent(Event{Type: EventStarted})
 	return nil
 }
 
@@ -110,24 +119,20 @@ func (ap *AudioPlayer) Stop() {
 	ap.mu.Lock()
 	defer ap.mu.Unlock()
 
-	// Cancel the context to stop the goroutine
-	if ap.cancelPlayback != nil {
-		ap.cancelPlayback()
-	}
-
-	// Wait for the goroutine to finish
-	ap.wg.Wait()
-
-	if ap.ffmpegCmd != nil {
-		_ = ap.ffmpegCmd.Process.Kill()
-		_ = ap.ffmpegCmd.Wait()
-		ap.ffmpegCmd = nil
-	}
+	stopFFmpegCmd(ap.cancelPlayback, ap.ffmpegCmd, &ap.wg)
+	ap.ffmpegCmd = nil
 
 	if ap.player != nil {
 		ap.player.Close()
 		ap.player = nil
 	}
+
+	if err := ap.stopRecordingLocked(); err != nil {
+		log.Printf("Error stopping recording: %v", err)
+	}
+
+	ap.reader = nil
+	ap.paused = false
 }
 
 // Close stops the audio player and closes the oto.Context.
@@ -139,20 +144,137 @@ func (ap *AudioPlayer) Close() {
 	}
 }
 
+// Pause stops writes from the FFmpeg stdout pipe to the Oto player without killing the
+// FFmpeg process, so the decoder pipeline stays warm and buffered. Call Resume to continue
+// playback from where it left off.
+func (ap *AudioPlayer) Pause() {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.reader == nil || ap.paused {
+		return
+	}
+	ap.reader.pause()
+	ap.paused = true
+	ap.emitEvent(Event{Type: EventPaused})
+}
+
+// Resume continues a paused AudioPlayer. It is a no-op if the player is not paused.
+func (ap *AudioPlayer) Resume() {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.reader == nil || !ap.paused {
+		return
+	}
+	ap.reader.resume()
+	ap.paused = false
+}
+
+// Seek restarts the FFmpeg pipeline at the given offset, in seconds, while reusing the
+// existing Oto context and, if the player was paused, restoring that paused state.
+func (ap *AudioPlayer) Seek(seconds int) error {
+	ap.mu.Lock()
+	wasPaused := ap.paused
+	ap.mu.Unlock()
+
+	ap.Stop()
+
+	ap.mu.Lock()
+	ap.startTime = seconds
+	ap.mu.Unlock()
+
+	if err := ap.Start(); err != nil {
+		return fmt.Errorf("seek to %ds: %w", seconds, err)
+	}
+
+	if wasPaused {
+		ap.Pause()
+	}
+	ap.emitEvent(Event{Type: EventSeeked})
+	return nil
+}
+
 type readerCtx struct {
 	ctx context.Context
 	r   io.Reader
+
+	mu       sync.Mutex
+	pausedCh chan struct{}
+	tap      *ringBuffer
 }
 
-// Read is the Read method of the io.Reader interface.
+// Read is the Read method of the io.Reader interface. While paused, Read blocks until
+// resume is called or the context is cancelled, keeping the underlying FFmpeg stdout pipe
+// buffered instead of torn down. If a recording tap is attached, the bytes read are also
+// copied to it.
 func (r *readerCtx) Read(p []byte) (n int, err error) {
 	if err := r.ctx.Err(); err != nil {
 		return 0, err
 	}
-	return r.r.Read(p)
+	if err := r.waitIfPaused(); err != nil {
+		return 0, err
+	}
+	n, err = r.r.Read(p)
+	if n > 0 {
+		if tap := r.getTap(); tap != nil {
+			tap.Write(p[:n])
+		}
+	}
+	return n, err
+}
+
+func (r *readerCtx) setTap(tap *ringBuffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tap = tap
+}
+
+func (r *readerCtx) getTap() *ringBuffer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tap
+}
+
+func (r *readerCtx) waitIfPaused() error {
+	for {
+		r.mu.Lock()
+		ch := r.pausedCh
+		r.mu.Unlock()
+
+		if ch == nil {
+			return nil
+		}
+		select {
+		case <-ch:
+		case <-r.ctx.Done():
+			return r.ctx.Err()
+		}
+	}
+}
+
+func (r *readerCtx) pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pausedCh == nil {
+		r.pausedCh = make(chan struct{})
+	}
+}
+
+func (r *readerCtx) resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pausedCh != nil {
+		close(r.pausedCh)
+		r.pausedCh = nil
+	}
+}
+
+func newReaderCtx(ctx context.Context, r io.Reader) *readerCtx {
+	return &readerCtx{ctx: ctx, r: r}
 }
 
 // NewReader returns a context-aware io.Reader.
 func NewReader(ctx context.Context, r io.Reader) io.Reader {
-	return &readerCtx{ctx: ctx, r: r}
+	return newReaderCtx(ctx, r)
 }
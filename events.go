@@ -0,0 +1,62 @@
+package audioplayer
+
+import "log"
+
+// eventBufferSize is the capacity of an AudioPlayer's event channel. Events() is
+// non-blocking past this point: once full, new events are dropped with a logged warning
+// rather than stalling the playback copy loop.
+const eventBufferSize = 32
+
+// EventType identifies the kind of playback Event emitted on AudioPlayer.Events().
+type EventType int
+
+const (
+	// EventStarted is emitted once playback begins, after Start successfully spins up the
+	// FFmpeg pipeline and Oto player.
+	EventStarted EventType = iota
+	// EventEOF is emitted when playback reaches the end of the stream naturally.
+	EventEOF
+	// EventError is emitted when the copy loop between FFmpeg and the Oto player fails for
+	// a reason other than an intentional Stop.
+	EventError
+	// EventPaused is emitted when Pause is called.
+	EventPaused
+	// EventSeeked is emitted after Seek successfully restarts the pipeline at a new offset.
+	EventSeeked
+)
+
+// Event is a single playback notification emitted on AudioPlayer.Events().
+type Event struct {
+	Type EventType
+	Err  error // set only for EventError
+}
+
+// Events returns a channel of playback events for this AudioPlayer. The channel is created
+// on first use and is buffered and non-blocking: slow consumers drop events rather than
+// stalling playback. The event channel has its own lock, separate from ap.mu, so Events can
+// be called, and events emitted from within Start and Pause, while ap.mu is held.
+func (ap *AudioPlayer) Events() <-chan Event {
+	ap.eventsMu.Lock()
+	defer ap.eventsMu.Unlock()
+
+	if ap.events == nil {
+		ap.events = make(chan Event, eventBufferSize)
+	}
+	return ap.events
+}
+
+// emitEvent sends evt to the event channel, if one has been created, without blocking.
+func (ap *AudioPlayer) emitEvent(evt Event) {
+	ap.eventsMu.Lock()
+	ch := ap.events
+	ap.eventsMu.Unlock()
+
+	if ch == nil {
+		return
+	}
+	select {
+	case ch <- evt:
+	default:
+		log.Printf("audioplayer: event channel full, dropping %v", evt.Type)
+	}
+}
@@ -0,0 +1,160 @@
+package audioplayer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+var ffprobePath string = "ffprobe"
+
+// SetFFprobePath sets the path to the FFprobe executable. This should be used if FFprobe is
+// not in the system PATH.
+func SetFFprobePath(path string) {
+	ffprobePath = path
+}
+
+// AudioMeta holds metadata about an audio file, as reported by FFprobe, along with any
+// embedded cover art found in the file.
+type AudioMeta struct {
+	Title      string
+	Artist     string
+	Album      string
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+
+	CoverData []byte
+	CoverMime string
+}
+
+type ffprobeFormat struct {
+	Duration string            `json:"duration"`
+	Tags     map[string]string `json:"tags"`
+}
+
+type ffprobeStream struct {
+	CodecType  string            `json:"codec_type"`
+	CodecName  string            `json:"codec_name"`
+	SampleRate string            `json:"sample_rate"`
+	Channels   int               `json:"channels"`
+	Tags       map[string]string `json:"tags"`
+}
+
+type ffprobeOutput struct {
+	Format  ffprobeFormat   `json:"format"`
+	Streams []ffprobeStream `json:"streams"`
+}
+
+// Probe shells out to FFprobe to read metadata and, if present, the embedded cover art of
+// audioFile.
+func Probe(audioFile string) (*AudioMeta, error) {
+	out, err := exec.Command(
+		ffprobePath,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		audioFile,
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("run ffprobe: %w", err)
+	}
+
+	meta, hasCoverStream, err := parseProbeOutput(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasCoverStream {
+		data, mime, err := probeCover(audioFile)
+		if err != nil {
+			return nil, fmt.Errorf("extract cover: %w", err)
+		}
+		meta.CoverData = data
+		meta.CoverMime = mime
+	}
+
+	return meta, nil
+}
+
+// parseProbeOutput parses FFprobe's JSON output into an AudioMeta, along with whether a video
+// (cover art) stream was present, which the caller uses to decide whether to run probeCover.
+// Split out from Probe so the parsing can be tested without shelling out to FFprobe.
+func parseProbeOutput(out []byte) (meta *AudioMeta, hasCoverStream bool, err error) {
+	var probed ffprobeOutput
+	if err := json.Unmarshal(out, &probed); err != nil {
+		return nil, false, fmt.Errorf("parse ffprobe output: %w", err)
+	}
+
+	meta = &AudioMeta{}
+	if d, err := strconv.ParseFloat(probed.Format.Duration, 64); err == nil {
+		meta.Duration = time.Duration(d * float64(time.Second))
+	}
+	meta.Title = formatTag(probed.Format.Tags, "title")
+	meta.Artist = formatTag(probed.Format.Tags, "artist")
+	meta.Album = formatTag(probed.Format.Tags, "album")
+
+	for _, stream := range probed.Streams {
+		switch stream.CodecType {
+		case "audio":
+			meta.Channels = stream.Channels
+			if rate, err := strconv.Atoi(stream.SampleRate); err == nil {
+				meta.SampleRate = rate
+			}
+			if meta.Title == "" {
+				meta.Title = formatTag(stream.Tags, "title")
+			}
+		case "video":
+			hasCoverStream = true
+		}
+	}
+
+	return meta, hasCoverStream, nil
+}
+
+func formatTag(tags map[string]string, key string) string {
+	if v, ok := tags[key]; ok {
+		return v
+	}
+	return ""
+}
+
+// probeCover runs a second FFmpeg pass over audioFile to extract the attached-picture video
+// stream as a cover image, returning its raw bytes and sniffed MIME type.
+func probeCover(audioFile string) ([]byte, string, error) {
+	tmp, err := os.CreateTemp("", "audioplayer-cover-*")
+	if err != nil {
+		return nil, "", fmt.Errorf("create temp cover file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	cmd := exec.Command(
+		ffmpegPath,
+		"-y",
+		"-i", audioFile,
+		"-map", "0:v",
+		"-c", "copy",
+		"-f", "image2",
+		tmpPath,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, "", fmt.Errorf("run ffmpeg: %w: %s", err, stderr.String())
+	}
+
+	data, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("read cover file: %w", err)
+	}
+
+	return data, http.DetectContentType(data), nil
+}
@@ -0,0 +1,69 @@
+package audioplayer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseProbeOutput(t *testing.T) {
+	const sample = `{
+		"format": {
+			"duration": "123.456000",
+			"tags": {"title": "Format Title", "artist": "The Artist", "album": "The Album"}
+		},
+		"streams": [
+			{"codec_type": "audio", "codec_name": "mp3", "sample_rate": "44100", "channels": 2},
+			{"codec_type": "video", "codec_name": "mjpeg"}
+		]
+	}`
+
+	meta, hasCover, err := parseProbeOutput([]byte(sample))
+	if err != nil {
+		t.Fatalf("parseProbeOutput() error = %v", err)
+	}
+	if !hasCover {
+		t.Error("hasCoverStream = false, want true for a video stream")
+	}
+
+	want := AudioMeta{
+		Title:      "Format Title",
+		Artist:     "The Artist",
+		Album:      "The Album",
+		Duration:   123456 * time.Millisecond,
+		SampleRate: 44100,
+		Channels:   2,
+	}
+	if meta.Title != want.Title || meta.Artist != want.Artist || meta.Album != want.Album ||
+		meta.Duration != want.Duration || meta.SampleRate != want.SampleRate || meta.Channels != want.Channels {
+		t.Errorf("parseProbeOutput() = %+v, want %+v", *meta, want)
+	}
+}
+
+func TestParseProbeOutputFallsBackToStreamTitle(t *testing.T) {
+	const sample = `{
+		"format": {"duration": "1.0", "tags": {}},
+		"streams": [
+			{"codec_type": "audio", "sample_rate": "48000", "channels": 1, "tags": {"title": "Stream Title"}}
+		]
+	}`
+
+	meta, hasCover, err := parseProbeOutput([]byte(sample))
+	if err != nil {
+		t.Fatalf("parseProbeOutput() error = %v", err)
+	}
+	if hasCover {
+		t.Error("hasCoverStream = true, want false with no video stream")
+	}
+	if meta.Title != "Stream Title" {
+		t.Errorf("Title = %q, want %q", meta.Title, "Stream Title")
+	}
+	if meta.SampleRate != 48000 || meta.Channels != 1 {
+		t.Errorf("SampleRate/Channels = %d/%d, want 48000/1", meta.SampleRate, meta.Channels)
+	}
+}
+
+func TestParseProbeOutputInvalidJSON(t *testing.T) {
+	if _, _, err := parseProbeOutput([]byte("not json")); err == nil {
+		t.Error("parseProbeOutput() error = nil, want error for invalid JSON")
+	}
+}
@@ -0,0 +1,240 @@
+package audioplayer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"sync"
+
+	"github.com/hajimehoshi/oto"
+)
+
+// Mixer owns the single oto.Context a process may create and lets multiple Tracks share it,
+// each with its own FFmpeg decode pipeline and oto.Player.
+type Mixer struct {
+	context *oto.Context
+}
+
+// NewMixer creates a Mixer backed by a single oto.Context. Only one Mixer (or AudioPlayer)
+// may be active in a process at a time, since oto only supports one context per process.
+func NewMixer() (*Mixer, error) {
+	bufferSizeInBytes := 4 * 1024
+
+	ctx, err := oto.NewContext(44100, 2, 2, bufferSizeInBytes)
+	if err != nil {
+		return nil, fmt.Errorf("create oto context: %w", err)
+	}
+	return &Mixer{context: ctx}, nil
+}
+
+// NewTrack returns a Track for audioFile that shares the Mixer's oto.Context. The track is
+// not started until Start is called on it.
+func (m *Mixer) NewTrack(audioFile string, speed float64, startTime int) *Track {
+	return &Track{
+		mixer:     m,
+		audioFile: audioFile,
+		speed:     speed,
+		startTime: startTime,
+		volume:    1,
+	}
+}
+
+// Close closes the Mixer's oto.Context. Any Tracks still playing are left with a dead
+// context and should be stopped first.
+func (m *Mixer) Close() {
+	if m.context != nil {
+		m.context.Close()
+		m.context = nil
+	}
+}
+
+// Track is a single audio stream played through a Mixer's shared oto.Context. Each Track
+// has its own FFmpeg process and oto.Player, so tracks mix independently and can be played,
+// stopped, and have their volume or pan adjusted without affecting one another.
+type Track struct {
+	mu sync.Mutex
+	wg sync.WaitGroup
+
+	mixer *Mixer
+
+	cancelPlayback context.CancelFunc
+	ffmpegCmd      *exec.Cmd
+	player         *oto.Player
+	gain           *gainReader
+	audioFile      string
+
+	speed     float64 // must be between 0.5 and 2.0
+	startTime int     // in seconds
+	volume    float64
+	pan       float64
+}
+
+// Start creates an FFmpeg process to decode the track's audio file into raw PCM data and
+// plays it through an oto.Player on the Mixer's shared context.
+func (t *Track) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.ffmpegCmd != nil {
+		t.mu.Unlock()
+		t.Stop()
+		t.mu.Lock()
+	}
+
+	pipeline, err := startFFmpegPipeline(FileSource(t.audioFile), t.speed, t.startTime, &t.wg)
+	if err != nil {
+		return err
+	}
+	t.cancelPlayback = pipeline.cancel
+
+	gain := newGainReader(pipeline.reader)
+	gain.setVolume(t.volume)
+	gain.setPan(t.pan)
+	t.gain = gain
+
+	t.wg.Add(1)
+	t.player = t.mixer.context.NewPlayer()
+	go func() {
+		if _, err := io.Copy(t.player, gain); err != nil {
+			log.Printf("Error copying audio data: %v", err)
+		}
+		t.wg.Done()
+		t.Stop()
+	}()
+
+	t.ffmpegCmd = pipeline.cmd
+	return nil
+}
+
+// Stop closes the track's oto.Player and kills its FFmpeg process, without touching the
+// Mixer's shared oto.Context.
+func (t *Track) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stopFFmpegCmd(t.cancelPlayback, t.ffmpegCmd, &t.wg)
+	t.ffmpegCmd = nil
+
+	if t.player != nil {
+		t.player.Close()
+		t.player = nil
+	}
+
+	t.gain = nil
+}
+
+// SetVolume sets the track's software gain, applied to the PCM stream before it reaches the
+// oto.Player. 1.0 is unity gain, 0.0 is silent.
+func (t *Track) SetVolume(volume float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.volume = volume
+	if t.gain != nil {
+		t.gain.setVolume(volume)
+	}
+}
+
+// SetPan sets the track's stereo balance, from -1.0 (full left) to 1.0 (full right), applied
+// as a software L/R gain on the interleaved s16le samples.
+func (t *Track) SetPan(pan float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pan = pan
+	if t.gain != nil {
+		t.gain.setPan(pan)
+	}
+}
+
+// gainReader applies a software volume and pan gain to an s16le, 2-channel PCM stream as it
+// is read, so Track.SetVolume/SetPan take effect without restarting the FFmpeg pipeline.
+type gainReader struct {
+	r io.Reader
+
+	mu     sync.Mutex
+	volume float64
+	pan    float64
+}
+
+func newGainReader(r io.Reader) *gainReader {
+	return &gainReader{r: r, volume: 1}
+}
+
+func (g *gainReader) Read(p []byte) (int, error) {
+	n, err := g.r.Read(p)
+	if n > 0 {
+		g.mu.Lock()
+		left, right := panGains(g.volume, g.pan)
+		g.mu.Unlock()
+
+		if left != 1 || right != 1 {
+			applyGain(p[:n], left, right)
+		}
+	}
+	return n, err
+}
+
+func (g *gainReader) setVolume(volume float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.volume = volume
+}
+
+func (g *gainReader) setPan(pan float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pan = pan
+}
+
+// panGains turns a volume and a -1.0..1.0 pan into independent left/right gains using a
+// simple linear pan law.
+func panGains(volume, pan float64) (left, right float64) {
+	if pan > 1 {
+		pan = 1
+	} else if pan < -1 {
+		pan = -1
+	}
+
+	left, right = volume, volume
+	if pan > 0 {
+		left *= 1 - pan
+	} else if pan < 0 {
+		right *= 1 + pan
+	}
+	return left, right
+}
+
+// applyGain scales each interleaved s16le stereo sample in buf in place. buf is assumed to
+// contain whole 4-byte frames; any trailing partial frame is left untouched.
+func applyGain(buf []byte, leftGain, rightGain float64) {
+	for i := 0; i+4 <= len(buf); i += 4 {
+		left := scaleSample(decodeSample(buf[i], buf[i+1]), leftGain)
+		right := scaleSample(decodeSample(buf[i+2], buf[i+3]), rightGain)
+		buf[i], buf[i+1] = encodeSample(left)
+		buf[i+2], buf[i+3] = encodeSample(right)
+	}
+}
+
+func decodeSample(lo, hi byte) int16 {
+	return int16(uint16(lo) | uint16(hi)<<8)
+}
+
+func encodeSample(s int16) (lo, hi byte) {
+	u := uint16(s)
+	return byte(u), byte(u >> 8)
+}
+
+func scaleSample(s int16, gain float64) int16 {
+	v := float64(s) * gain
+	switch {
+	case v > 32767:
+		return 32767
+	case v < -32768:
+		return -32768
+	default:
+		return int16(v)
+	}
+}
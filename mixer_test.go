@@ -0,0 +1,85 @@
+package audioplayer
+
+import "testing"
+
+func TestPanGains(t *testing.T) {
+	tests := []struct {
+		name         string
+		volume, pan  float64
+		wantL, wantR float64
+	}{
+		{"center", 1, 0, 1, 1},
+		{"full right", 1, 1, 0, 1},
+		{"full left", 1, -1, 1, 0},
+		{"half right", 1, 0.5, 0.5, 1},
+		{"clamps above 1", 1, 2, 0, 1},
+		{"clamps below -1", 1, -2, 1, 0},
+		{"volume scales both", 0.5, 0, 0.5, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := panGains(tt.volume, tt.pan)
+			if left != tt.wantL || right != tt.wantR {
+				t.Errorf("panGains(%v, %v) = (%v, %v), want (%v, %v)", tt.volume, tt.pan, left, right, tt.wantL, tt.wantR)
+			}
+		})
+	}
+}
+
+func TestDecodeEncodeSampleRoundTrip(t *testing.T) {
+	for _, s := range []int16{0, 1, -1, 32767, -32768, 12345, -12345} {
+		lo, hi := encodeSample(s)
+		got := decodeSample(lo, hi)
+		if got != s {
+			t.Errorf("decodeSample(encodeSample(%d)) = %d, want %d", s, got, s)
+		}
+	}
+}
+
+func TestScaleSample(t *testing.T) {
+	tests := []struct {
+		s    int16
+		gain float64
+		want int16
+	}{
+		{1000, 1, 1000},
+		{1000, 0.5, 500},
+		{32767, 2, 32767},   // clamps to max
+		{-32768, 2, -32768}, // clamps to min
+		{0, 10, 0},
+	}
+	for _, tt := range tests {
+		if got := scaleSample(tt.s, tt.gain); got != tt.want {
+			t.Errorf("scaleSample(%d, %v) = %d, want %d", tt.s, tt.gain, got, tt.want)
+		}
+	}
+}
+
+func TestApplyGain(t *testing.T) {
+	loL, hiL := encodeSample(1000)
+	loR, hiR := encodeSample(2000)
+	buf := []byte{loL, hiL, loR, hiR}
+
+	applyGain(buf, 0.5, 2)
+
+	gotL := decodeSample(buf[0], buf[1])
+	gotR := decodeSample(buf[2], buf[3])
+	if gotL != 500 {
+		t.Errorf("left sample = %d, want 500", gotL)
+	}
+	if gotR != 4000 {
+		t.Errorf("right sample = %d, want 4000", gotR)
+	}
+}
+
+func TestApplyGainIgnoresTrailingPartialFrame(t *testing.T) {
+	loL, hiL := encodeSample(1000)
+	loR, hiR := encodeSample(1000)
+	buf := []byte{loL, hiL, loR, hiR, 0xEF}
+
+	applyGain(buf, 0.5, 0.5)
+
+	if buf[4] != 0xEF {
+		t.Errorf("trailing partial frame was modified: got %#x, want 0xef", buf[4])
+	}
+}
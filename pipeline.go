@@ -0,0 +1,103 @@
+package audioplayer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+)
+
+// ffmpegPipeline is the FFmpeg-spawn-and-decode machinery shared by AudioPlayer and Track:
+// resolve a Source into an FFmpeg "-i" input, start FFmpeg decoding it to raw PCM, and hand
+// back a context-aware reader over its stdout. Extracted so the two don't duplicate (and
+// drift on) this bookkeeping.
+type ffmpegPipeline struct {
+	cmd           *exec.Cmd
+	reader        *readerCtx
+	cancel        context.CancelFunc
+	resolvedInput string
+}
+
+// startFFmpegPipeline resolves source and starts FFmpeg decoding it into s16le/44100Hz/stereo
+// PCM at the given speed and start offset. wg tracks any goroutines it starts (e.g. piping a
+// Source's reader into FFmpeg's stdin), so the caller's own Wait sees them too.
+func startFFmpegPipeline(source Source, speed float64, startTime int, wg *sync.WaitGroup) (*ffmpegPipeline, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	input, err := source.Input(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("resolve input source: %w", err)
+	}
+
+	ffmpegCmd := exec.Command(
+		ffmpegPath,
+		"-ss", strconv.Itoa(startTime),
+		"-i", input,
+		"-filter:a", "atempo="+strconv.FormatFloat(speed, 'f', -1, 64),
+		"-f", "s16le",
+		"-ar", "44100",
+		"-ac", "2",
+		"pipe:1",
+	)
+
+	var stdinFile *os.File
+	if stdin := source.Stdin(); stdin != nil {
+		stdinFile, err = connectStdin(ctx, ffmpegCmd, stdin, cancel, wg)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		// Closed explicitly once cmd has its own copy of the descriptor (the success path
+		// below); this covers every early-return between here and there.
+		defer func() {
+			if stdinFile != nil {
+				stdinFile.Close()
+			}
+		}()
+	}
+
+	ffmpegOut, err := ffmpegCmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("create FFmpeg stdout pipe: %w", err)
+	}
+
+	reader := newReaderCtx(ctx, ffmpegOut)
+
+	if err := ffmpegCmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start FFmpeg cmd: %w", err)
+	}
+
+	if stdinFile != nil {
+		// cmd has its own copy of the descriptor now; close ours so it isn't leaked, and
+		// clear it so the deferred cleanup above doesn't double-close it.
+		stdinFile.Close()
+		stdinFile = nil
+	}
+
+	return &ffmpegPipeline{
+		cmd:           ffmpegCmd,
+		reader:        reader,
+		cancel:        cancel,
+		resolvedInput: input,
+	}, nil
+}
+
+// stopFFmpegCmd cancels cancel (if set), waits for wg, then kills and waits on cmd (if set).
+// Shared by AudioPlayer.Stop and Track.Stop to avoid duplicating this teardown bookkeeping.
+func stopFFmpegCmd(cancel context.CancelFunc, cmd *exec.Cmd, wg *sync.WaitGroup) {
+	if cancel != nil {
+		cancel()
+	}
+
+	wg.Wait()
+
+	if cmd != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}
+}
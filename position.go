@@ -0,0 +1,61 @@
+package audioplayer
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// bytesPerSecond is the size of one second of the s16le, 44100Hz, stereo PCM that FFmpeg
+// decodes into, matching the "-ar 44100 -ac 2 -f s16le" arguments used to start it.
+const bytesPerSecond = 44100 * 2 * 2
+
+// Position returns the current playback position, derived from the number of PCM bytes
+// written to the Oto player so far, scaled by speed, plus the offset playback started at.
+func (ap *AudioPlayer) Position() time.Duration {
+	ap.mu.Lock()
+	startTime := ap.startTime
+	speed := ap.speed
+	ap.mu.Unlock()
+
+	written := atomic.LoadInt64(&ap.bytesWritten)
+	elapsed := time.Duration(float64(written) / bytesPerSecond * speed * float64(time.Second))
+	return time.Duration(startTime)*time.Second + elapsed
+}
+
+// Duration returns the total duration of the audio being played, via an FFprobe call against
+// the input Start resolved the current Source to. It is not available before Start has been
+// called, nor for streaming sources (those whose Input resolves to "pipe:0"), since FFprobe
+// has nothing to open directly in that case.
+func (ap *AudioPlayer) Duration() (time.Duration, error) {
+	ap.mu.Lock()
+	input := ap.resolvedInput
+	ap.mu.Unlock()
+
+	if input == "" {
+		return 0, fmt.Errorf("start playback before calling Duration")
+	}
+	if input == "pipe:0" {
+		return 0, fmt.Errorf("duration is not available for streaming sources")
+	}
+
+	meta, err := Probe(input)
+	if err != nil {
+		return 0, fmt.Errorf("probe duration: %w", err)
+	}
+	return meta.Duration, nil
+}
+
+// countingWriter forwards writes to w while counting the total bytes written in n, so
+// AudioPlayer.Position can report progress without the copy loop knowing about it.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(c.n, int64(n))
+	return n, err
+}
@@ -0,0 +1,43 @@
+package audioplayer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime int
+		speed     float64
+		written   int64
+		want      time.Duration
+	}{
+		{"no bytes written yet", 0, 1, 0, 0},
+		{"one second at normal speed", 0, 1, bytesPerSecond, time.Second},
+		{"double speed doubles elapsed", 0, 2, bytesPerSecond, 2 * time.Second},
+		{"adds start offset", 10, 1, bytesPerSecond, 11 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := &AudioPlayer{startTime: tt.startTime, speed: tt.speed, bytesWritten: tt.written}
+			if got := ap.Position(); got != tt.want {
+				t.Errorf("Position() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationBeforeStart(t *testing.T) {
+	ap := &AudioPlayer{}
+	if _, err := ap.Duration(); err == nil {
+		t.Error("Duration() before Start: error = nil, want error")
+	}
+}
+
+func TestDurationStreamingSource(t *testing.T) {
+	ap := &AudioPlayer{resolvedInput: "pipe:0"}
+	if _, err := ap.Duration(); err == nil {
+		t.Error("Duration() for a streaming source: error = nil, want error")
+	}
+}
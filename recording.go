@@ -0,0 +1,253 @@
+package audioplayer
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"strconv"
+	"sync"
+)
+
+// defaultRecordBufferSize is the size, in bytes, of the ring buffer used to tee PCM data to
+// the recording encoder. At 44100Hz/16-bit/stereo this holds a little under six seconds
+// of audio, which is enough slack for a slow encoder without stalling playback.
+const defaultRecordBufferSize = 1 << 20
+
+// RecordOptions configures a recording started with AudioPlayer.StartRecording.
+type RecordOptions struct {
+	SampleRate int // defaults to 44100
+	Channels   int // defaults to 2
+
+	Bitrate string // MP3 bitrate passed to FFmpeg's -b:a, e.g. "192k"; defaults to "192k"
+
+	// Writer, if set, receives the encoded MP3 stream instead of the path passed to
+	// StartRecording.
+	Writer io.Writer
+}
+
+// recorder ties the second, MP3-encoding FFmpeg process to the ring buffer that feeds it.
+type recorder struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	tap   *ringBuffer
+	wg    sync.WaitGroup
+}
+
+// StartRecording tees the raw PCM coming out of the playback FFmpeg process into a second
+// FFmpeg process that encodes it as MP3, written to path or, if opts.Writer is set, to that
+// writer instead. Playback must already be started. The tee is bounded: if the encoder falls
+// behind, excess PCM is dropped with a logged warning rather than stalling playback.
+func (ap *AudioPlayer) StartRecording(path string, opts RecordOptions) error {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.reader == nil {
+		return fmt.Errorf("start playback before recording")
+	}
+	if ap.recorder != nil {
+		return fmt.Errorf("recording already in progress")
+	}
+
+	sampleRate := opts.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 44100
+	}
+	channels := opts.Channels
+	if channels == 0 {
+		channels = 2
+	}
+	bitrate := opts.Bitrate
+	if bitrate == "" {
+		bitrate = "192k"
+	}
+
+	// The tap always carries PCM in the main playback pipeline's fixed format (44100Hz,
+	// stereo, s16le - see startFFmpegPipeline). SampleRate/Channels describe the desired *output* format,
+	// so they're applied as a resample/downmix after -i, not as the input format, or FFmpeg
+	// would misinterpret the actual bytes it's fed.
+	args := []string{
+		"-y",
+		"-f", "s16le",
+		"-ar", "44100",
+		"-ac", "2",
+		"-i", "pipe:0",
+		"-ar", strconv.Itoa(sampleRate),
+		"-ac", strconv.Itoa(channels),
+		"-b:a", bitrate,
+		"-f", "mp3",
+	}
+	if opts.Writer != nil {
+		args = append(args, "pipe:1")
+	} else {
+		args = append(args, path)
+	}
+
+	cmd := exec.Command(ffmpegPath, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("create recording FFmpeg stdin pipe: %w", err)
+	}
+
+	var stdout io.ReadCloser
+	if opts.Writer != nil {
+		stdout, err = cmd.StdoutPipe()
+		if err != nil {
+			return fmt.Errorf("create recording FFmpeg stdout pipe: %w", err)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start recording FFmpeg cmd: %w", err)
+	}
+
+	rec := &recorder{
+		cmd:   cmd,
+		stdin: stdin,
+		tap:   newRingBuffer(defaultRecordBufferSize),
+	}
+
+	rec.wg.Add(1)
+	go func() {
+		defer rec.wg.Done()
+		if _, err := io.Copy(stdin, rec.tap); err != nil {
+			log.Printf("Error copying recording data: %v", err)
+		}
+		stdin.Close()
+	}()
+
+	if opts.Writer != nil {
+		rec.wg.Add(1)
+		go func() {
+			defer rec.wg.Done()
+			if _, err := io.Copy(opts.Writer, stdout); err != nil {
+				log.Printf("Error copying recording output: %v", err)
+			}
+		}()
+	}
+
+	ap.recorder = rec
+	ap.reader.setTap(rec.tap)
+	return nil
+}
+
+// StopRecording stops an in-progress recording started with StartRecording and waits for the
+// encoder to finish. It is a no-op if no recording is in progress.
+func (ap *AudioPlayer) StopRecording() error {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	return ap.stopRecordingLocked()
+}
+
+// stopRecordingLocked tears down an in-progress recording, if any. ap.mu must already be
+// held by the caller; it is also called from Stop so that playback ending for any reason
+// doesn't abandon a running recording.
+func (ap *AudioPlayer) stopRecordingLocked() error {
+	rec := ap.recorder
+	ap.recorder = nil
+	if ap.reader != nil {
+		ap.reader.setTap(nil)
+	}
+
+	if rec == nil {
+		return nil
+	}
+
+	rec.tap.Close()
+
+	// rec is already detached from ap, so it's safe to wait on its goroutines and process
+	// with ap.mu released. That wait has no deadline: a RecordOptions.Writer that
+	// backpressures or is never drained can block it indefinitely, and every other method
+	// needs ap.mu to make progress in the meantime.
+	ap.mu.Unlock()
+	rec.wg.Wait()
+	waitErr := rec.cmd.Wait()
+	ap.mu.Lock()
+
+	if waitErr != nil {
+		return fmt.Errorf("wait for recording FFmpeg cmd: %w", waitErr)
+	}
+	return nil
+}
+
+// ringBuffer is a fixed-size, concurrency-safe byte ring buffer used to tee PCM data to the
+// recording encoder. Writes never block: once full, incoming data is dropped and a warning is
+// logged, so a slow encoder can't stall audio playback.
+type ringBuffer struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	buf    []byte
+	r, w   int
+	length int
+	closed bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	rb := &ringBuffer{buf: make([]byte, size)}
+	rb.cond = sync.NewCond(&rb.mu)
+	return rb
+}
+
+// Write appends p to the buffer. If there isn't enough room for all of p, the write is
+// dropped in its entirety and a warning is logged; it never blocks or returns an error for
+// the drop case so the caller's copy loop keeps running.
+func (rb *ringBuffer) Write(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if rb.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	free := len(rb.buf) - rb.length
+	if len(p) > free {
+		log.Printf("audioplayer: recording buffer full, dropping %d bytes", len(p))
+		return len(p), nil
+	}
+
+	n := copy(rb.buf[rb.w:], p)
+	if n < len(p) {
+		copy(rb.buf, p[n:])
+	}
+	rb.w = (rb.w + len(p)) % len(rb.buf)
+	rb.length += len(p)
+	rb.cond.Broadcast()
+	return len(p), nil
+}
+
+// Read blocks until data is available or the buffer is closed.
+func (rb *ringBuffer) Read(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for rb.length == 0 && !rb.closed {
+		rb.cond.Wait()
+	}
+	if rb.length == 0 {
+		return 0, io.EOF
+	}
+
+	toRead := len(p)
+	if toRead > rb.length {
+		toRead = rb.length
+	}
+	n := copy(p, rb.buf[rb.r:])
+	if n < toRead {
+		n += copy(p[n:toRead], rb.buf)
+	}
+	rb.r = (rb.r + toRead) % len(rb.buf)
+	rb.length -= toRead
+	return toRead, nil
+}
+
+// Close marks the buffer closed, unblocking any pending Read with io.EOF and failing any
+// subsequent Write.
+func (rb *ringBuffer) Close() error {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.closed = true
+	rb.cond.Broadcast()
+	return nil
+}
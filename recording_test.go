@@ -0,0 +1,92 @@
+package audioplayer
+
+import (
+	"io"
+	"testing"
+)
+
+func TestRingBufferWriteReadRoundTrip(t *testing.T) {
+	rb := newRingBuffer(8)
+
+	if n, err := rb.Write([]byte{1, 2, 3}); err != nil || n != 3 {
+		t.Fatalf("Write() = (%d, %v), want (3, nil)", n, err)
+	}
+
+	buf := make([]byte, 3)
+	n, err := rb.Read(buf)
+	if err != nil || n != 3 {
+		t.Fatalf("Read() = (%d, %v), want (3, nil)", n, err)
+	}
+	if string(buf) != string([]byte{1, 2, 3}) {
+		t.Errorf("Read() = %v, want [1 2 3]", buf)
+	}
+}
+
+func TestRingBufferWraparound(t *testing.T) {
+	rb := newRingBuffer(8)
+
+	// Fill, drain most of it, then write again so the write wraps past the end of buf.
+	if _, err := rb.Write([]byte{1, 2, 3, 4, 5, 6}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	drained := make([]byte, 5)
+	if _, err := rb.Read(drained); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	// w is now at index 6, r at index 5, length 1. Writing 6 more bytes wraps w around.
+	if _, err := rb.Write([]byte{7, 8, 9, 10, 11, 12}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got := make([]byte, 7)
+	n, err := rb.Read(got)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []byte{6, 7, 8, 9, 10, 11, 12}
+	if n != len(want) || string(got[:n]) != string(want) {
+		t.Errorf("Read() = %v, want %v", got[:n], want)
+	}
+}
+
+func TestRingBufferDropsWriteWhenFull(t *testing.T) {
+	rb := newRingBuffer(4)
+
+	if _, err := rb.Write([]byte{1, 2, 3, 4}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// The buffer is full; this write should be dropped (with a logged warning), not block or error.
+	n, err := rb.Write([]byte{5})
+	if err != nil || n != 1 {
+		t.Fatalf("Write() = (%d, %v), want (1, nil)", n, err)
+	}
+
+	got := make([]byte, 4)
+	if _, err := rb.Read(got); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if string(got) != string([]byte{1, 2, 3, 4}) {
+		t.Errorf("Read() = %v, want [1 2 3 4], dropped write should not have been stored", got)
+	}
+}
+
+func TestRingBufferReadAfterCloseReturnsEOF(t *testing.T) {
+	rb := newRingBuffer(4)
+	rb.Close()
+
+	n, err := rb.Read(make([]byte, 4))
+	if n != 0 || err != io.EOF {
+		t.Errorf("Read() after Close = (%d, %v), want (0, io.EOF)", n, err)
+	}
+}
+
+func TestRingBufferWriteAfterCloseErrors(t *testing.T) {
+	rb := newRingBuffer(4)
+	rb.Close()
+
+	if _, err := rb.Write([]byte{1}); err != io.ErrClosedPipe {
+		t.Errorf("Write() after Close error = %v, want io.ErrClosedPipe", err)
+	}
+}
@@ -0,0 +1,108 @@
+package audioplayer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// Source supplies FFmpeg with the audio to decode. Implementations either resolve to a
+// path or URL that FFmpeg can open directly, or provide a reader whose bytes are piped into
+// FFmpeg's stdin.
+type Source interface {
+	// Input returns the value to pass as FFmpeg's "-i" argument. Returning "pipe:0" means
+	// FFmpeg should read from stdin, which Stdin must then supply.
+	Input(ctx context.Context) (string, error)
+
+	// Stdin returns the reader to pipe into FFmpeg's stdin when Input returns "pipe:0". It
+	// returns nil for sources FFmpeg reads directly, such as files and HTTP(S) URLs.
+	Stdin() io.Reader
+}
+
+// FileSource returns a Source that plays a local audio file.
+func FileSource(path string) Source {
+	return &fileSource{path: path}
+}
+
+type fileSource struct {
+	path string
+}
+
+func (s *fileSource) Input(ctx context.Context) (string, error) { return s.path, nil }
+func (s *fileSource) Stdin() io.Reader                          { return nil }
+
+// NewHTTPSource returns a Source that plays an HTTP(S) URL. FFmpeg's built-in http protocol
+// handler fetches the stream directly, so no reader needs to be piped in.
+func NewHTTPSource(url string) Source {
+	return &httpSource{url: url}
+}
+
+type httpSource struct {
+	url string
+}
+
+func (s *httpSource) Input(ctx context.Context) (string, error) { return s.url, nil }
+func (s *httpSource) Stdin() io.Reader                          { return nil }
+
+// NewReaderSource returns a Source that pipes an arbitrary io.Reader into FFmpeg's stdin.
+// Sources that fetch and stream bytes themselves rather than hand FFmpeg a URL it can open
+// on its own, such as a youtube.Source, use the same "pipe:0" and Stdin mechanism.
+func NewReaderSource(r io.Reader) Source {
+	return &readerSource{r: r}
+}
+
+type readerSource struct {
+	r io.Reader
+}
+
+func (s *readerSource) Input(ctx context.Context) (string, error) { return "pipe:0", nil }
+func (s *readerSource) Stdin() io.Reader                          { return s.r }
+
+// connectStdin feeds src into cmd's stdin through an OS pipe, rather than handing src to
+// cmd.Stdin directly. A plain io.Reader there makes Cmd.Wait block on an internal copy
+// goroutine until src.Read returns, even once the process has been killed; an *os.File
+// avoids that wait entirely. connectStdin also arranges for src to be closed (if it's an
+// io.Closer, e.g. an HTTP response body) as soon as ctx is cancelled, which is what actually
+// unblocks a src.Read stuck on a stalled network connection. wg is used to track the
+// goroutines it starts, so callers can wait for them to fully unwind.
+//
+// The returned *os.File is cmd's read end of the pipe. The caller must Close it once cmd has
+// started, as cmd only takes over the child's copy of the descriptor.
+func connectStdin(ctx context.Context, cmd *exec.Cmd, src io.Reader, cancel context.CancelFunc, wg *sync.WaitGroup) (*os.File, error) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("create stdin pipe: %w", err)
+	}
+	cmd.Stdin = pr
+
+	var closeSrcOnce sync.Once
+	closeSrc := func() {
+		closeSrcOnce.Do(func() {
+			if closer, ok := src.(io.Closer); ok {
+				closer.Close()
+			}
+		})
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		closeSrc()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer pw.Close()
+		defer closeSrc()
+		if _, err := io.Copy(pw, src); err != nil && ctx.Err() == nil {
+			cancel()
+		}
+	}()
+
+	return pr, nil
+}
@@ -0,0 +1,80 @@
+// Package youtube provides an audioplayer.Source backed by a YouTube video, so AudioPlayer
+// can play a video's audio track without the caller handling extraction itself.
+package youtube
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	ytdl "github.com/kkdai/youtube/v2"
+	"github.com/mmaviglia/audioplayer"
+)
+
+// Source resolves a YouTube video's best audio-only format and streams it into FFmpeg's
+// stdin. It implements audioplayer.Source.
+type Source struct {
+	url string
+
+	client ytdl.Client
+
+	mu     sync.Mutex
+	stream io.ReadCloser
+}
+
+// NewSource returns a Source for the given YouTube video URL or ID.
+func NewSource(url string) *Source {
+	return &Source{url: url}
+}
+
+// Input resolves the video's best audio-only format and opens it for streaming, returning
+// "pipe:0" so AudioPlayer pipes the result into FFmpeg's stdin via Stdin.
+func (s *Source) Input(ctx context.Context) (string, error) {
+	video, err := s.client.GetVideoContext(ctx, s.url)
+	if err != nil {
+		return "", fmt.Errorf("get youtube video: %w", err)
+	}
+
+	// Type("audio") selects formats whose MimeType is actually "audio/...". WithAudioChannels
+	// alone isn't enough: it also matches muxed progressive formats (video+audio), which
+	// would have FFmpeg pull down and decode video data for what's supposed to be
+	// audio-only playback.
+	formats := video.Formats.Type("audio")
+	if len(formats) == 0 {
+		return "", fmt.Errorf("no audio-only format available for %q", s.url)
+	}
+	best := formats[0]
+	for _, f := range formats[1:] {
+		if f.Bitrate > best.Bitrate {
+			best = f
+		}
+	}
+
+	stream, _, err := s.client.GetStreamContext(ctx, video, &best)
+	if err != nil {
+		return "", fmt.Errorf("get youtube stream: %w", err)
+	}
+
+	s.mu.Lock()
+	prev := s.stream
+	s.stream = stream
+	s.mu.Unlock()
+
+	// A previous, never-drained stream (e.g. from a retried or abandoned Input call) would
+	// otherwise leak its underlying HTTP connection.
+	if prev != nil {
+		prev.Close()
+	}
+
+	return "pipe:0", nil
+}
+
+// Stdin returns the stream resolved by the most recent call to Input.
+func (s *Source) Stdin() io.Reader {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream
+}
+
+var _ audioplayer.Source = (*Source)(nil)